@@ -0,0 +1,66 @@
+// Package cmd wires the challenge-cli subcommands to the runner
+// package.
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/jcsawyer123/challenge-cli/leetcode_cli/runner"
+)
+
+// RunBenchCompare implements `challenge-cli bench compare`. It tabulates
+// the last N recorded runs for a problem/language and flags a
+// regression when the recent median wall time or peak memory exceeds
+// the prior baseline by more than the configured threshold; a wall-time
+// regression additionally requires Welch's t-test to give p < 0.05.
+func RunBenchCompare(out io.Writer, args []string) error {
+	fs := flag.NewFlagSet("bench compare", flag.ContinueOnError)
+	problemID := fs.String("problem", "", "problem id to compare (required)")
+	language := fs.String("language", "", "language to compare (required)")
+	last := fs.Int("last", 20, "number of most recent runs to tabulate")
+	recentWindow := fs.Int("recent", 5, "number of most recent runs treated as the \"recent\" sample")
+	threshold := fs.Float64("threshold", runner.RegressionThreshold, "fractional regression threshold")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *problemID == "" || *language == "" {
+		return fmt.Errorf("bench compare: --problem and --language are required")
+	}
+
+	entries, err := runner.LoadBenchHistory(*problemID, *language)
+	if err != nil {
+		return fmt.Errorf("bench compare: %w", err)
+	}
+	if len(entries) > *last {
+		entries = entries[len(entries)-*last:]
+	}
+
+	fmt.Fprintf(out, "%-20s %-10s %10s %12s %4s\n", "timestamp", "commit", "wall(ms)", "max_rss(kb)", "exit")
+	for _, e := range entries {
+		fmt.Fprintf(out, "%-20s %-10s %10d %12d %4d\n",
+			e.Timestamp.Format("2006-01-02T15:04:05"), e.CommitHash, e.WallTimeMS, e.MaxRSSKB, e.ExitCode)
+	}
+
+	report, ok := runner.DetectRegression(entries, *recentWindow, *threshold)
+	if !ok {
+		fmt.Fprintf(out, "\nnot enough samples to compare (need at least %d)\n", 2*(*recentWindow))
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nbaseline median: wall=%.1fms rss=%.0fkb\n", report.BaselineWallMedianMS, report.BaselineRSSMedianKB)
+	fmt.Fprintf(out, "recent   median: wall=%.1fms rss=%.0fkb (p=%.3f)\n", report.RecentWallMedianMS, report.RecentRSSMedianKB, report.WallPValue)
+
+	if report.WallRegressed {
+		fmt.Fprintf(out, "REGRESSION: wall time up more than %.0f%%\n", *threshold*100)
+	}
+	if report.RSSRegressed {
+		fmt.Fprintf(out, "REGRESSION: peak memory up more than %.0f%%\n", *threshold*100)
+	}
+	if !report.WallRegressed && !report.RSSRegressed {
+		fmt.Fprintln(out, "no regression detected")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/jcsawyer123/challenge-cli/leetcode_cli/runner"
+)
+
+// RunAll implements `challenge-cli run --all`. It discovers every test
+// case for problemDir, executes them concurrently via runCase (one
+// container per test case) bounded by --parallel, prints an aggregated
+// pass/fail table, and records a BenchEntry per test case so `bench
+// compare` has history to work from.
+func RunAll(out io.Writer, problemID, language, problemDir string, args []string, runCase runner.RunCaseFunc) error {
+	fs := flag.NewFlagSet("run --all", flag.ContinueOnError)
+	parallel := fs.Int("parallel", runtime.NumCPU(), "number of test cases to run concurrently")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	testCases, err := runner.DiscoverTestCases(problemDir)
+	if err != nil {
+		return fmt.Errorf("run --all: %w", err)
+	}
+	if len(testCases) == 0 {
+		return fmt.Errorf("run --all: no test cases found under %s", problemDir)
+	}
+
+	results := runner.RunAll(testCases, *parallel, runCase)
+
+	commitHash, err := runner.CurrentCommitHash()
+	if err != nil {
+		commitHash = "unknown"
+	}
+
+	passed := 0
+	fmt.Fprintf(out, "%-30s %-6s %10s %12s\n", "test case", "result", "wall(ms)", "max_rss(kb)")
+	for _, r := range results {
+		status := "FAIL"
+		if r.Pass {
+			status = "PASS"
+			passed++
+		}
+		fmt.Fprintf(out, "%-30s %-6s %10d %12d\n", r.TestCase, status, r.WallTimeMS, r.MaxRSSKB)
+		if r.Err != nil {
+			fmt.Fprintf(out, "  error: %v\n", r.Err)
+		}
+
+		entry := runner.BenchEntry{
+			ProblemID:  problemID,
+			Language:   language,
+			CommitHash: commitHash,
+			WallTimeMS: r.WallTimeMS,
+			MaxRSSKB:   r.MaxRSSKB,
+			ExitCode:   r.ExitCode,
+			Timestamp:  time.Now(),
+		}
+		if err := runner.RecordBenchEntry(entry); err != nil {
+			fmt.Fprintf(out, "warning: recording bench history: %v\n", err)
+		}
+	}
+
+	fmt.Fprintf(out, "\n%d/%d test cases passed\n", passed, len(results))
+	if passed != len(results) {
+		return fmt.Errorf("run --all: %d test case(s) failed", len(results)-passed)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Package runner drives solution execution inside the per-language
+// Docker images defined under plugins/dockerfiles.
+package runner
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// RuntimeAdapter encapsulates everything the runner needs to build and
+// execute a solution for a single language: which image to run it in,
+// how to compile the source (if at all), and the argv used to invoke it.
+type RuntimeAdapter interface {
+	// Name is the short language identifier, e.g. "go", "python".
+	Name() string
+
+	// Image is the fully-qualified tag of the runtime image, e.g.
+	// "challenge-cli/runtime-go:latest".
+	Image() string
+
+	// ArtifactName is the file or directory CompileCmd writes its
+	// output to, relative to outDir, e.g. "solution" or "build". It is
+	// empty for interpreted languages that have no compile step, which
+	// NeedsCompile uses to skip the build cache entirely for them.
+	ArtifactName() string
+
+	// CompileCmd returns the argv used to compile srcPath into a binary
+	// or bytecode artifact under outDir, or nil if the language has no
+	// separate compile step (interpreted languages).
+	CompileCmd(srcPath, outDir string) []string
+
+	// ExecArgv returns the argv used to run the compiled artifact (read
+	// from outDir) or source file inside the container.
+	ExecArgv(srcPath, outDir string) []string
+}
+
+// adapters maps a solution file extension (including the leading dot)
+// to the RuntimeAdapter responsible for it.
+var adapters = map[string]RuntimeAdapter{
+	".go":   goAdapter{},
+	".py":   pythonAdapter{},
+	".java": javaAdapter{},
+	".cpp":  cppAdapter{},
+	".cc":   cppAdapter{},
+	".js":   nodeAdapter{},
+	".rs":   rustAdapter{},
+}
+
+// AdapterForFile picks the RuntimeAdapter matching the extension of
+// solutionPath. It returns an error if no adapter is registered for
+// that extension.
+func AdapterForFile(solutionPath string) (RuntimeAdapter, error) {
+	ext := strings.ToLower(filepath.Ext(solutionPath))
+	adapter, ok := adapters[ext]
+	if !ok {
+		return nil, fmt.Errorf("runner: no RuntimeAdapter registered for extension %q", ext)
+	}
+	return adapter, nil
+}
+
+type goAdapter struct{}
+
+func (goAdapter) Name() string         { return "go" }
+func (goAdapter) Image() string        { return "challenge-cli/runtime-go:latest" }
+func (goAdapter) ArtifactName() string { return "solution" }
+func (goAdapter) CompileCmd(srcPath, outDir string) []string {
+	return []string{"go", "build", "-o", path.Join(outDir, "solution"), srcPath}
+}
+func (goAdapter) ExecArgv(srcPath, outDir string) []string {
+	return []string{path.Join(outDir, "solution")}
+}
+
+type pythonAdapter struct{}
+
+func (pythonAdapter) Name() string                               { return "python" }
+func (pythonAdapter) Image() string                              { return "challenge-cli/runtime-python:latest" }
+func (pythonAdapter) ArtifactName() string                       { return "" }
+func (pythonAdapter) CompileCmd(srcPath, outDir string) []string { return nil }
+func (pythonAdapter) ExecArgv(srcPath, outDir string) []string {
+	return []string{"python3", srcPath}
+}
+
+type javaAdapter struct{}
+
+func (javaAdapter) Name() string         { return "java" }
+func (javaAdapter) Image() string        { return "challenge-cli/runtime-java:latest" }
+func (javaAdapter) ArtifactName() string { return "build" }
+func (javaAdapter) CompileCmd(srcPath, outDir string) []string {
+	return []string{"javac", "-d", path.Join(outDir, "build"), srcPath}
+}
+func (javaAdapter) ExecArgv(srcPath, outDir string) []string {
+	class := strings.TrimSuffix(filepath.Base(srcPath), filepath.Ext(srcPath))
+	return []string{"java", "-cp", path.Join(outDir, "build"), class}
+}
+
+type cppAdapter struct{}
+
+func (cppAdapter) Name() string         { return "cpp" }
+func (cppAdapter) Image() string        { return "challenge-cli/runtime-cpp:latest" }
+func (cppAdapter) ArtifactName() string { return "solution" }
+func (cppAdapter) CompileCmd(srcPath, outDir string) []string {
+	return []string{"g++", "-O2", "-std=c++20", "-o", path.Join(outDir, "solution"), srcPath}
+}
+func (cppAdapter) ExecArgv(srcPath, outDir string) []string {
+	return []string{path.Join(outDir, "solution")}
+}
+
+type nodeAdapter struct{}
+
+func (nodeAdapter) Name() string                               { return "node" }
+func (nodeAdapter) Image() string                              { return "challenge-cli/runtime-node:latest" }
+func (nodeAdapter) ArtifactName() string                       { return "" }
+func (nodeAdapter) CompileCmd(srcPath, outDir string) []string { return nil }
+func (nodeAdapter) ExecArgv(srcPath, outDir string) []string {
+	return []string{"node", srcPath}
+}
+
+type rustAdapter struct{}
+
+func (rustAdapter) Name() string         { return "rust" }
+func (rustAdapter) Image() string        { return "challenge-cli/runtime-rust:latest" }
+func (rustAdapter) ArtifactName() string { return "solution" }
+func (rustAdapter) CompileCmd(srcPath, outDir string) []string {
+	return []string{"rustc", "-O", "-o", path.Join(outDir, "solution"), srcPath}
+}
+func (rustAdapter) ExecArgv(srcPath, outDir string) []string {
+	return []string{path.Join(outDir, "solution")}
+}
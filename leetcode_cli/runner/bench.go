@@ -0,0 +1,110 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// benchHistoryFile is the JSONL file every run/bench invocation appends
+// an entry to, relative to the user's home directory.
+const benchHistoryFile = ".challenge-cli/bench.jsonl"
+
+// BenchEntry is one recorded invocation of `run` or `bench`, as appended
+// to bench.jsonl. Each field mirrors a column GNU time or the runner
+// itself already measures, so recording an entry costs nothing extra
+// at call sites.
+type BenchEntry struct {
+	ProblemID  string    `json:"problem_id"`
+	Language   string    `json:"language"`
+	CommitHash string    `json:"commit_hash"`
+	WallTimeMS int64     `json:"wall_time_ms"`
+	MaxRSSKB   int64     `json:"max_rss_kb"`
+	ExitCode   int       `json:"exit_code"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// benchHistoryPath returns the absolute path to bench.jsonl, creating
+// its parent directory if necessary.
+func benchHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("runner: resolving home directory: %w", err)
+	}
+	path := filepath.Join(home, benchHistoryFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("runner: creating bench history directory: %w", err)
+	}
+	return path, nil
+}
+
+// RecordBenchEntry appends entry to bench.jsonl as a single JSON line.
+func RecordBenchEntry(entry BenchEntry) error {
+	path, err := benchHistoryPath()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("runner: opening bench history: %w", err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("runner: marshaling bench entry: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("runner: writing bench entry: %w", err)
+	}
+	return nil
+}
+
+// CurrentCommitHash returns the short hash of HEAD in the current
+// working directory, for stamping BenchEntry.CommitHash. Callers running
+// outside a git checkout should treat a non-nil error as non-fatal and
+// fall back to a placeholder, since bench history is still useful
+// without it.
+func CurrentCommitHash() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("runner: resolving commit hash: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// LoadBenchHistory reads every entry recorded for problemID/language
+// from bench.jsonl, oldest first.
+func LoadBenchHistory(problemID, language string) ([]BenchEntry, error) {
+	path, err := benchHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("runner: reading bench history: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var entries []BenchEntry
+	for dec.More() {
+		var entry BenchEntry
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("runner: decoding bench entry: %w", err)
+		}
+		if entry.ProblemID == problemID && entry.Language == language {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,233 @@
+package runner
+
+import "math"
+
+// RegressionThreshold is the default fractional increase in median wall
+// time or peak memory that triggers a regression flag in `bench
+// compare`.
+const RegressionThreshold = 0.10
+
+// RegressionReport summarizes a comparison between a baseline window of
+// bench entries and the most recent ones.
+type RegressionReport struct {
+	BaselineWallMedianMS float64
+	RecentWallMedianMS   float64
+	BaselineRSSMedianKB  float64
+	RecentRSSMedianKB    float64
+	WallRegressed        bool
+	RSSRegressed         bool
+	// WallPValue is the two-sided p-value from Welch's t-test over wall
+	// time samples, used to avoid flagging single-run noise.
+	WallPValue float64
+}
+
+// DetectRegression splits entries into a baseline window (everything
+// but the last k) and a recent window (the last k). It flags a wall-time
+// regression when the recent median wall time exceeds the baseline
+// median by more than threshold AND Welch's t-test rejects the null
+// hypothesis that the two wall-time samples share a mean (p < 0.05). It
+// flags a peak-RSS regression on the median threshold alone; no
+// significance test is applied to RSS. Returns ok=false if there are not
+// enough samples to compare.
+func DetectRegression(entries []BenchEntry, k int, threshold float64) (report RegressionReport, ok bool) {
+	if len(entries) < 2*k || k < 1 {
+		return RegressionReport{}, false
+	}
+
+	baseline := entries[:len(entries)-k]
+	recent := entries[len(entries)-k:]
+
+	baselineWall := wallTimes(baseline)
+	recentWall := wallTimes(recent)
+	baselineRSS := rssValues(baseline)
+	recentRSS := rssValues(recent)
+
+	report.BaselineWallMedianMS = median(baselineWall)
+	report.RecentWallMedianMS = median(recentWall)
+	report.BaselineRSSMedianKB = median(baselineRSS)
+	report.RecentRSSMedianKB = median(recentRSS)
+	report.WallPValue = welchTTestPValue(baselineWall, recentWall)
+
+	wallExceeds := exceedsThreshold(report.BaselineWallMedianMS, report.RecentWallMedianMS, threshold)
+	rssExceeds := exceedsThreshold(report.BaselineRSSMedianKB, report.RecentRSSMedianKB, threshold)
+
+	report.WallRegressed = wallExceeds && report.WallPValue < 0.05
+	report.RSSRegressed = rssExceeds
+
+	return report, true
+}
+
+func exceedsThreshold(baseline, recent, threshold float64) bool {
+	if baseline <= 0 {
+		return false
+	}
+	return (recent-baseline)/baseline > threshold
+}
+
+func wallTimes(entries []BenchEntry) []float64 {
+	out := make([]float64, len(entries))
+	for i, e := range entries {
+		out[i] = float64(e.WallTimeMS)
+	}
+	return out
+}
+
+func rssValues(entries []BenchEntry) []float64 {
+	out := make([]float64, len(entries))
+	for i, e := range entries {
+		out[i] = float64(e.MaxRSSKB)
+	}
+	return out
+}
+
+func median(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), samples...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j-1] > sorted[j]; j-- {
+			sorted[j-1], sorted[j] = sorted[j], sorted[j-1]
+		}
+	}
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func mean(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range samples {
+		sum += v
+	}
+	return sum / float64(len(samples))
+}
+
+func variance(samples []float64, m float64) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	var sumSq float64
+	for _, v := range samples {
+		d := v - m
+		sumSq += d * d
+	}
+	return sumSq / float64(len(samples)-1)
+}
+
+// welchTTestPValue returns the exact two-sided p-value of Welch's
+// t-test between a and b, computed from the Welch-Satterthwaite degrees
+// of freedom via the regularized incomplete beta function, so no
+// external stats dependency is needed.
+func welchTTestPValue(a, b []float64) float64 {
+	if len(a) < 2 || len(b) < 2 {
+		return 1
+	}
+	meanA, meanB := mean(a), mean(b)
+	varA, varB := variance(a, meanA), variance(b, meanB)
+	nA, nB := float64(len(a)), float64(len(b))
+
+	se := math.Sqrt(varA/nA + varB/nB)
+	if se == 0 {
+		return 1
+	}
+	t := math.Abs(meanA-meanB) / se
+
+	df := math.Pow(varA/nA+varB/nB, 2) /
+		(math.Pow(varA/nA, 2)/(nA-1) + math.Pow(varB/nB, 2)/(nB-1))
+
+	return studentTTwoSidedPValue(t, df)
+}
+
+// studentTTwoSidedPValue returns the exact two-sided p-value for
+// Student's t distribution with df degrees of freedom: P(|T| > |t|) =
+// I_x(df/2, 1/2), where x = df/(df+t^2) and I is the regularized
+// incomplete beta function.
+func studentTTwoSidedPValue(t, df float64) float64 {
+	x := df / (df + t*t)
+	return regularizedIncompleteBeta(x, df/2, 0.5)
+}
+
+// regularizedIncompleteBeta evaluates I_x(a, b) using the continued
+// fraction representation (Numerical Recipes §6.4), which converges
+// quickly over the symmetric half of its domain used here.
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	lgammaAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction used by
+// regularizedIncompleteBeta via Lentz's algorithm.
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIter = 200
+		epsilon = 1e-12
+		tiny    = 1e-300
+	)
+
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIter; m++ {
+		m2 := float64(2 * m)
+
+		aa := float64(m) * (b - float64(m)) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + float64(m)) * (qab + float64(m)) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+
+	return h
+}
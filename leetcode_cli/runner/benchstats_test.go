@@ -0,0 +1,83 @@
+package runner
+
+import "testing"
+
+func TestMedian(t *testing.T) {
+	cases := []struct {
+		name    string
+		samples []float64
+		want    float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{5}, 5},
+		{"odd", []float64{3, 1, 2}, 2},
+		{"even", []float64{4, 1, 3, 2}, 2.5},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := median(c.samples); got != c.want {
+				t.Errorf("median(%v) = %v, want %v", c.samples, got, c.want)
+			}
+		})
+	}
+}
+
+// TestStudentTTwoSidedPValue checks the p-value formula against the
+// textbook two-sided 5% critical t-values, where p should land at
+// almost exactly 0.05.
+func TestStudentTTwoSidedPValue(t *testing.T) {
+	cases := []struct {
+		df   float64
+		crit float64
+	}{
+		{1, 12.706},
+		{10, 2.228},
+		{30, 2.042},
+		{120, 1.980},
+	}
+
+	for _, c := range cases {
+		got := studentTTwoSidedPValue(c.crit, c.df)
+		if diff := got - 0.05; diff < -0.002 || diff > 0.002 {
+			t.Errorf("studentTTwoSidedPValue(%v, df=%v) = %v, want ~0.05", c.crit, c.df, got)
+		}
+	}
+}
+
+func TestWelchTTestPValueTooFewSamples(t *testing.T) {
+	if got := welchTTestPValue([]float64{1}, []float64{1, 2, 3}); got != 1 {
+		t.Errorf("welchTTestPValue with <2 samples = %v, want 1", got)
+	}
+}
+
+func TestDetectRegressionFlagsSustainedSlowdown(t *testing.T) {
+	baselineWall := []int64{98, 101, 99, 102, 100, 97, 103, 100, 99, 101}
+	recentWall := []int64{200, 198, 203, 197, 201}
+
+	var entries []BenchEntry
+	for _, w := range baselineWall {
+		entries = append(entries, BenchEntry{WallTimeMS: w, MaxRSSKB: 1000})
+	}
+	for _, w := range recentWall {
+		entries = append(entries, BenchEntry{WallTimeMS: w, MaxRSSKB: 1000})
+	}
+
+	report, ok := DetectRegression(entries, 5, RegressionThreshold)
+	if !ok {
+		t.Fatal("DetectRegression returned ok=false with enough samples")
+	}
+	if !report.WallRegressed {
+		t.Errorf("expected a wall-time regression to be flagged, got report=%+v", report)
+	}
+	if report.RSSRegressed {
+		t.Errorf("RSS did not change, should not be flagged")
+	}
+}
+
+func TestDetectRegressionNotEnoughSamples(t *testing.T) {
+	entries := []BenchEntry{{WallTimeMS: 100}, {WallTimeMS: 100}}
+	if _, ok := DetectRegression(entries, 5, RegressionThreshold); ok {
+		t.Error("expected ok=false when fewer than 2*k samples are available")
+	}
+}
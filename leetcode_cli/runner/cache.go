@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// defaultCachePath is where compiled artifacts are memoized when
+// CACHE_PATH is not set.
+const defaultCachePath = ".challenge-cli/cache"
+
+// defaultCacheTTL is how long a cache entry is kept before EvictExpired
+// removes it, when CACHE_TTL is not set.
+const defaultCacheTTL = 7 * 24 * time.Hour
+
+// CacheOptions controls the on-disk memoization of compile artifacts.
+type CacheOptions struct {
+	// Enabled mounts a content-addressed cache directory into the
+	// container at /cache so the compile step can reuse a prior
+	// artifact instead of recompiling.
+	Enabled bool
+	// Path is the cache root on the host, e.g. ~/.challenge-cli/cache.
+	Path string
+	// TTL is how long an entry may sit unused before EvictExpired
+	// removes it.
+	TTL time.Duration
+}
+
+// CacheOptionsFromEnv builds CacheOptions from CACHE_ENABLED, CACHE_PATH
+// and CACHE_TTL, falling back to ~/.challenge-cli/cache and a 7-day TTL
+// when unset.
+func CacheOptionsFromEnv() (CacheOptions, error) {
+	opts := CacheOptions{
+		Enabled: os.Getenv("CACHE_ENABLED") != "false" && os.Getenv("CACHE_ENABLED") != "0",
+		Path:    os.Getenv("CACHE_PATH"),
+		TTL:     defaultCacheTTL,
+	}
+
+	if opts.Path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return CacheOptions{}, fmt.Errorf("runner: resolving home directory: %w", err)
+		}
+		opts.Path = filepath.Join(home, defaultCachePath)
+	}
+
+	if raw := os.Getenv("CACHE_TTL"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil {
+			return CacheOptions{}, fmt.Errorf("runner: parsing CACHE_TTL %q: %w", raw, err)
+		}
+		opts.TTL = time.Duration(seconds) * time.Second
+	}
+
+	return opts, nil
+}
+
+// SourceHash returns the content-addressed cache key for a solution: the
+// SHA-256 of the source bytes, the language identifier, and the
+// compiler version banner, so a toolchain upgrade invalidates stale
+// artifacts without needing an explicit cache clear.
+func SourceHash(srcPath, language, compilerVersion string) (string, error) {
+	src, err := os.ReadFile(srcPath)
+	if err != nil {
+		return "", fmt.Errorf("runner: reading source for cache key: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(src)
+	h.Write([]byte("\x00" + language + "\x00" + compilerVersion))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// EntryDir returns the cache directory for the given source hash,
+// creating it if it does not already exist.
+func (c CacheOptions) EntryDir(hash string) (string, error) {
+	dir := filepath.Join(c.Path, hash)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("runner: creating cache entry %s: %w", hash, err)
+	}
+	return dir, nil
+}
+
+// Touch bumps the cache entry's mtime to now. NeedsCompile calls this on
+// every cache hit, so an entry's mtime reflects its last use (read or
+// write) rather than only its last write — which is what EvictExpired's
+// TTL is measured against.
+func (c CacheOptions) Touch(hash string) error {
+	dir := filepath.Join(c.Path, hash)
+	now := time.Now()
+	if err := os.Chtimes(dir, now, now); err != nil {
+		return fmt.Errorf("runner: touching cache entry %s: %w", hash, err)
+	}
+	return nil
+}
+
+// EvictExpired removes cache entries whose directory has not been
+// touched — by a compile (write) or a cache hit via NeedsCompile
+// (Touch) — within c.TTL. It is meant to run once at CLI startup.
+func (c CacheOptions) EvictExpired() error {
+	entries, err := os.ReadDir(c.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("runner: reading cache directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-c.TTL)
+	for _, entry := range entries {
+		path := filepath.Join(c.Path, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("runner: evicting cache entry %s: %w", entry.Name(), err)
+			}
+		}
+	}
+	return nil
+}
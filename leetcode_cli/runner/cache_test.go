@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNeedsCompileInterpretedLanguageNeverCompiles(t *testing.T) {
+	needs, err := NeedsCompile(pythonAdapter{}, SandboxOptions{})
+	if err != nil {
+		t.Fatalf("NeedsCompile: %v", err)
+	}
+	if needs {
+		t.Error("interpreted language should never need a compile step")
+	}
+}
+
+func TestNeedsCompileCacheDisabledAlwaysCompiles(t *testing.T) {
+	needs, err := NeedsCompile(goAdapter{}, SandboxOptions{})
+	if err != nil {
+		t.Fatalf("NeedsCompile: %v", err)
+	}
+	if !needs {
+		t.Error("caching disabled should always require a compile step")
+	}
+}
+
+func TestNeedsCompileCacheMissThenHit(t *testing.T) {
+	cache := CacheOptions{Enabled: true, Path: t.TempDir(), TTL: time.Hour}
+	opts := SandboxOptions{Cache: cache, CacheHash: "abc123"}
+
+	needs, err := NeedsCompile(goAdapter{}, opts)
+	if err != nil {
+		t.Fatalf("NeedsCompile (miss): %v", err)
+	}
+	if !needs {
+		t.Fatal("expected a cache miss on first invocation")
+	}
+
+	entryDir, err := cache.EntryDir(opts.CacheHash)
+	if err != nil {
+		t.Fatalf("EntryDir: %v", err)
+	}
+	artifact := filepath.Join(entryDir, goAdapter{}.ArtifactName())
+	if err := os.WriteFile(artifact, []byte("binary"), 0o755); err != nil {
+		t.Fatalf("writing fake artifact: %v", err)
+	}
+
+	staleTime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(entryDir, staleTime, staleTime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	needs, err = NeedsCompile(goAdapter{}, opts)
+	if err != nil {
+		t.Fatalf("NeedsCompile (hit): %v", err)
+	}
+	if needs {
+		t.Error("expected a cache hit once the artifact exists")
+	}
+
+	info, err := os.Stat(entryDir)
+	if err != nil {
+		t.Fatalf("Stat entry dir: %v", err)
+	}
+	if !info.ModTime().After(staleTime) {
+		t.Error("cache hit should have touched the entry's mtime")
+	}
+}
@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+// testCaseGlob matches every test case input file for a problem.
+const testCaseGlob = "testcases/*.in"
+
+// TestResult is the outcome of running a solution against a single test
+// case, in a form both `run --all` and `bench` can report uniformly.
+type TestResult struct {
+	TestCase   string
+	Pass       bool
+	WallTimeMS int64
+	MaxRSSKB   int64
+	ExitCode   int
+	Err        error
+}
+
+// DiscoverTestCases returns every testcases/*.in file under problemDir,
+// sorted for stable reporting order.
+func DiscoverTestCases(problemDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(problemDir, testCaseGlob))
+	if err != nil {
+		return nil, fmt.Errorf("runner: globbing test cases: %w", err)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// RunCaseFunc executes a solution against a single test case and
+// reports the result. Implementations are expected to run the
+// container themselves (e.g. via DockerArgs) and measure wall time and
+// peak RSS per invocation.
+type RunCaseFunc func(testCase string) TestResult
+
+// RunAll dispatches testCases across a bounded pool of parallel workers
+// and returns one TestResult per test case, in the same order as
+// testCases. A parallel value of 0 or less defaults to
+// runtime.NumCPU(), matching `run --all --parallel`'s default.
+func RunAll(testCases []string, parallel int, run RunCaseFunc) []TestResult {
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+	if parallel > len(testCases) {
+		parallel = len(testCases)
+	}
+
+	results := make([]TestResult, len(testCases))
+	if parallel == 0 {
+		return results
+	}
+
+	jobs := make(chan int)
+	done := make(chan struct{})
+
+	for w := 0; w < parallel; w++ {
+		go func() {
+			for i := range jobs {
+				results[i] = run(testCases[i])
+			}
+			done <- struct{}{}
+		}()
+	}
+
+	go func() {
+		for i := range testCases {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	for w := 0; w < parallel; w++ {
+		<-done
+	}
+
+	return results
+}
@@ -0,0 +1,83 @@
+package runner
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunAllPreservesOrder(t *testing.T) {
+	testCases := []string{"a.in", "b.in", "c.in", "d.in", "e.in"}
+
+	results := RunAll(testCases, 3, func(tc string) TestResult {
+		return TestResult{TestCase: tc, Pass: true}
+	})
+
+	if len(results) != len(testCases) {
+		t.Fatalf("got %d results, want %d", len(results), len(testCases))
+	}
+	for i, tc := range testCases {
+		if results[i].TestCase != tc {
+			t.Errorf("results[%d].TestCase = %q, want %q (order not preserved)", i, results[i].TestCase, tc)
+		}
+	}
+}
+
+func TestRunAllBoundsConcurrency(t *testing.T) {
+	testCases := make([]string, 20)
+	for i := range testCases {
+		testCases[i] = fmt.Sprintf("case-%d.in", i)
+	}
+
+	var inFlight, maxInFlight int64
+	block := make(chan struct{})
+	started := make(chan struct{}, len(testCases))
+
+	go func() {
+		for i := 0; i < 4; i++ {
+			<-started
+		}
+		close(block)
+	}()
+
+	RunAll(testCases, 4, func(tc string) TestResult {
+		cur := atomic.AddInt64(&inFlight, 1)
+		for {
+			old := atomic.LoadInt64(&maxInFlight)
+			if cur <= old || atomic.CompareAndSwapInt64(&maxInFlight, old, cur) {
+				break
+			}
+		}
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-block
+		atomic.AddInt64(&inFlight, -1)
+		return TestResult{TestCase: tc, Pass: true}
+	})
+
+	if got := atomic.LoadInt64(&maxInFlight); got > 4 {
+		t.Errorf("max concurrent workers = %d, want <= 4", got)
+	}
+}
+
+func TestRunAllDefaultsParallelToNumCPU(t *testing.T) {
+	testCases := []string{"only.in"}
+	results := RunAll(testCases, 0, func(tc string) TestResult {
+		return TestResult{TestCase: tc, Pass: true}
+	})
+	if len(results) != 1 || results[0].TestCase != "only.in" {
+		t.Errorf("RunAll with parallel=0 did not run the test case: %+v", results)
+	}
+}
+
+func TestRunAllEmpty(t *testing.T) {
+	results := RunAll(nil, 4, func(tc string) TestResult {
+		t.Fatal("run should not be called for an empty test case list")
+		return TestResult{}
+	})
+	if len(results) != 0 {
+		t.Errorf("RunAll(nil, ...) = %v, want empty", results)
+	}
+}
@@ -0,0 +1,189 @@
+package runner
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+//go:embed seccomp/deterministic.json
+var seccompProfileData []byte
+
+var (
+	seccompProfileOnce sync.Once
+	seccompProfileFile string
+	seccompProfileErr  error
+)
+
+// seccompProfilePath materializes the embedded seccomp profile to a
+// temp file and returns its absolute path. docker resolves
+// --security-opt seccomp=<path> against its own client working
+// directory, not the repo root, so a relative path breaks as soon as
+// the CLI runs from anywhere else; writing the embedded profile out and
+// returning the resulting absolute path sidesteps that entirely.
+func seccompProfilePath() (string, error) {
+	seccompProfileOnce.Do(func() {
+		f, err := os.CreateTemp("", "challenge-cli-seccomp-*.json")
+		if err != nil {
+			seccompProfileErr = fmt.Errorf("runner: writing seccomp profile: %w", err)
+			return
+		}
+		defer f.Close()
+		if _, err := f.Write(seccompProfileData); err != nil {
+			seccompProfileErr = fmt.Errorf("runner: writing seccomp profile: %w", err)
+			return
+		}
+		seccompProfileFile = f.Name()
+	})
+	return seccompProfileFile, seccompProfileErr
+}
+
+// fakeTimeShimPath is the in-container path of the compiled LD_PRELOAD
+// shim (see plugins/dockerfiles/faketime_shim.c, built into every
+// runtime image by its Dockerfile). Unlike libfaketime's static FAKETIME
+// mode, which merely freezes the clock, this shim starts the clock at a
+// fixed epoch (2000-01-01T00:00:00Z, hardcoded in the shim) and advances
+// it only by the duration requested in each sleep/usleep/nanosleep call,
+// which returns immediately without blocking, so two runs of the same
+// solution observe identical timestamps regardless of host scheduling
+// while time still "passes" across an intentional sleep. The shim has no env var to
+// configure the epoch, so there's nothing to pass via `docker run -e`.
+const fakeTimeShimPath = "/usr/local/lib/faketime_shim.so"
+
+// cpuBudgetSeconds caps the total CPU time (in seconds, across all
+// threads) a deterministic run may consume, enforced via `--ulimit
+// cpu=`. Unlike `--cpus` (a throughput limiter that caps how many cores
+// a container may use concurrently, without ever killing it),
+// `--ulimit cpu=` delivers SIGXCPU and then SIGKILL to the container's
+// process once the budget is exceeded.
+const cpuBudgetSeconds = "10"
+
+// containerSrcPath returns where srcPath is bind-mounted inside the
+// container (see the -v flag in BuildArgs/DockerArgs): /workspace, under
+// its original basename so language tooling that derives meaning from
+// the file name (e.g. javac requiring Main.java to hold `class Main`)
+// keeps working. Adapters must compile/exec this path, not the host
+// srcPath passed to BuildArgs/DockerArgs, which doesn't exist inside the
+// container.
+func containerSrcPath(srcPath string) string {
+	return path.Join("/workspace", filepath.Base(srcPath))
+}
+
+// SandboxOptions controls how a solution is executed inside its runtime
+// container.
+type SandboxOptions struct {
+	// Deterministic enables the fake-clock, network-locked-down,
+	// syscall-filtered sandbox used for reproducible grading.
+	Deterministic bool
+	// Cache, when Enabled, mounts the content-addressed build cache
+	// entry for this solution into the container at /cache.
+	Cache CacheOptions
+	// CacheHash is the SourceHash for the solution being run; required
+	// when Cache.Enabled is true.
+	CacheHash string
+}
+
+// outDir returns the container-side directory a solution's compiled
+// artifact is written to and read from: /cache when the build cache is
+// enabled, so the artifact survives across invocations, or /workspace
+// otherwise.
+func outDir(opts SandboxOptions) string {
+	if opts.Cache.Enabled {
+		return "/cache"
+	}
+	return "/workspace"
+}
+
+// NeedsCompile reports whether adapter's artifact must be (re)built for
+// this invocation. Interpreted languages (ArtifactName() == "") never
+// need a compile step. Otherwise, with caching disabled it is always
+// true; with caching enabled it is true only on a cache miss — and a
+// hit touches the entry so EvictExpired's TTL is measured from last use,
+// not just last write.
+func NeedsCompile(adapter RuntimeAdapter, opts SandboxOptions) (bool, error) {
+	if adapter.ArtifactName() == "" {
+		return false, nil
+	}
+	if !opts.Cache.Enabled {
+		return true, nil
+	}
+
+	entryDir, err := opts.Cache.EntryDir(opts.CacheHash)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := os.Stat(filepath.Join(entryDir, adapter.ArtifactName())); err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	if err := opts.Cache.Touch(opts.CacheHash); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// BuildArgs builds the `docker run` argument list for compiling srcPath
+// under adapter. Callers should only invoke this when NeedsCompile
+// returns true.
+func BuildArgs(adapter RuntimeAdapter, srcPath string, opts SandboxOptions) ([]string, error) {
+	dir := outDir(opts)
+	inContainer := containerSrcPath(srcPath)
+	args := []string{"run", "--rm", "-v", fmt.Sprintf("%s:%s:ro", srcPath, inContainer)}
+
+	if opts.Cache.Enabled {
+		entryDir, err := opts.Cache.EntryDir(opts.CacheHash)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-v", fmt.Sprintf("%s:/cache", entryDir))
+	}
+
+	args = append(args, adapter.Image())
+	args = append(args, adapter.CompileCmd(inContainer, dir)...)
+	return args, nil
+}
+
+// DockerArgs builds the `docker run` argument list for executing srcPath
+// under adapter, applying opts on top of the adapter's own image and
+// exec argv. The compiled artifact (if any) is read from /cache when
+// the build cache is enabled and from /workspace otherwise, matching
+// wherever BuildArgs's compile step (if NeedsCompile required one) wrote
+// it.
+func DockerArgs(adapter RuntimeAdapter, srcPath string, opts SandboxOptions) ([]string, error) {
+	inContainer := containerSrcPath(srcPath)
+	args := []string{"run", "--rm", "-v", fmt.Sprintf("%s:%s:ro", srcPath, inContainer)}
+
+	if opts.Deterministic {
+		profilePath, err := seccompProfilePath()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args,
+			"--network", "none",
+			"--cap-drop", "ALL",
+			"--security-opt", fmt.Sprintf("seccomp=%s", profilePath),
+			"--ulimit", fmt.Sprintf("cpu=%s", cpuBudgetSeconds),
+			"-e", fmt.Sprintf("LD_PRELOAD=%s", fakeTimeShimPath),
+		)
+	}
+
+	dir := outDir(opts)
+	if opts.Cache.Enabled {
+		entryDir, err := opts.Cache.EntryDir(opts.CacheHash)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "-v", fmt.Sprintf("%s:/cache", entryDir))
+	}
+
+	args = append(args, adapter.Image())
+	args = append(args, adapter.ExecArgv(inContainer, dir)...)
+	return args, nil
+}
@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDockerArgsUsesContainerPathNotHostPath(t *testing.T) {
+	args, err := DockerArgs(goAdapter{}, "/home/alice/solutions/two-sum.go", SandboxOptions{})
+	if err != nil {
+		t.Fatalf("DockerArgs: %v", err)
+	}
+
+	for _, a := range args {
+		if strings.Contains(a, "/home/alice") && !strings.HasPrefix(a, "/home/alice/solutions/two-sum.go:") {
+			t.Errorf("host path %q leaked into an argument meant for inside the container: %q", "/home/alice/solutions/two-sum.go", a)
+		}
+	}
+
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "/workspace/two-sum.go") {
+		t.Errorf("expected the in-container source path in the docker args, got %v", args)
+	}
+}
+
+func TestBuildArgsUsesContainerPathNotHostPath(t *testing.T) {
+	args, err := BuildArgs(goAdapter{}, "/home/alice/solutions/two-sum.go", SandboxOptions{})
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "go build -o /workspace/solution /workspace/two-sum.go") {
+		t.Errorf("CompileCmd did not receive the in-container source path: %v", args)
+	}
+}
+
+func TestJavaExecArgvKeepsOriginalClassName(t *testing.T) {
+	inContainer := containerSrcPath("/home/alice/solutions/Main.java")
+	argv := javaAdapter{}.ExecArgv(inContainer, "/workspace")
+
+	want := []string{"java", "-cp", "/workspace/build", "Main"}
+	if len(argv) != len(want) {
+		t.Fatalf("ExecArgv = %v, want %v", argv, want)
+	}
+	for i := range want {
+		if argv[i] != want[i] {
+			t.Errorf("ExecArgv[%d] = %q, want %q", i, argv[i], want[i])
+		}
+	}
+}
+
+func TestContainerSrcPathPreservesBasename(t *testing.T) {
+	got := containerSrcPath("/home/alice/solutions/Main.java")
+	want := "/workspace/Main.java"
+	if got != want {
+		t.Errorf("containerSrcPath(...) = %q, want %q", got, want)
+	}
+}